@@ -2,42 +2,108 @@ package wiremock
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 )
 
 const (
 	wiremockAdminURN         = "__admin"
 	wiremockAdminMappingsURN = "__admin/mappings"
+
+	defaultTimeout = 30 * time.Second
 )
 
 // A Client implements requests to the wiremock server.
 type Client struct {
-	url       string
-	stubs     map[*testing.T][]*StubRule
-	stubMutex sync.Mutex
+	url         string
+	httpClient  *http.Client
+	stubs       map[*testing.T][]*StubRule
+	testClients map[*testing.T]*TestClient
+	stubMutex   sync.Mutex
 }
 
 // NewClient returns *Client.
 func NewClient(url string) *Client {
 	return &Client{
-		url:   url,
-		stubs: make(map[*testing.T][]*StubRule),
+		url:        url,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		stubs:      make(map[*testing.T][]*StubRule),
+	}
+}
+
+// NewClientWithOptions returns *Client configured with the given Options,
+// e.g. WithTimeout, WithRetry, WithBasicAuth.
+func NewClientWithOptions(url string, opts ...Option) *Client {
+	c := NewClient(url)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// URL ...
+func (c *Client) URL() string {
+	return c.url
+}
+
+// doRequest executes req through the client's configured *http.Client,
+// carrying ctx's deadline/cancellation.
+func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.httpClient.Do(req.WithContext(ctx))
+}
+
+func (c *Client) adminURL(urn string, parts ...string) string {
+	url := fmt.Sprintf("%s/%s", c.url, urn)
+	for _, part := range parts {
+		url = fmt.Sprintf("%s/%s", url, part)
+	}
+	return url
+}
+
+func newJSONRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	var bodyReader *bytes.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	} else {
+		bodyReader = bytes.NewReader(nil)
 	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return req, nil
 }
 
 // StubFor creates a new stub mapping.
 func (c *Client) StubFor(stubRule *StubRule) error {
+	return c.StubForCtx(context.Background(), stubRule)
+}
+
+// StubForCtx creates a new stub mapping, honoring ctx's deadline.
+func (c *Client) StubForCtx(ctx context.Context, stubRule *StubRule) error {
 	requestBody, err := stubRule.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("build stub request error: %s", err.Error())
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s", c.url, wiremockAdminMappingsURN), "application/json", bytes.NewBuffer(requestBody))
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminMappingsURN), requestBody)
+	if err != nil {
+		return fmt.Errorf("build stub request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return fmt.Errorf("stub request error: %s", err.Error())
 	}
@@ -57,27 +123,8 @@ func (c *Client) StubFor(stubRule *StubRule) error {
 
 // StubForTest creates a new stub mapping for given test t
 func (c *Client) StubForTest(t *testing.T, stubRule *StubRule) {
-	requestBody, err := stubRule.MarshalJSON()
-	if err != nil {
-		t.Fatalf("build stub request error: %s", err)
-	}
-
-	res, err := http.Post(fmt.Sprintf("%s/%s", c.url, wiremockAdminMappingsURN), "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		t.Fatalf("stub request error: %s", err)
-	}
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			t.Errorf("close response body error: %s", err)
-		}
-	}()
-
-	if res.StatusCode != http.StatusCreated {
-		bodyBytes, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			t.Fatalf("read response error: %s", err)
-		}
-		t.Fatalf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if err := c.StubFor(stubRule); err != nil {
+		t.Fatalf("%s", err)
 	}
 
 	c.stubMutex.Lock()
@@ -91,12 +138,17 @@ func (c *Client) StubForTest(t *testing.T, stubRule *StubRule) {
 
 // Clear deletes all stub mappings.
 func (c *Client) Clear() error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.url, wiremockAdminMappingsURN), nil)
+	return c.ClearCtx(context.Background())
+}
+
+// ClearCtx deletes all stub mappings, honoring ctx's deadline.
+func (c *Client) ClearCtx(ctx context.Context) error {
+	req, err := newJSONRequest(ctx, http.MethodDelete, c.adminURL(wiremockAdminMappingsURN), nil)
 	if err != nil {
 		return fmt.Errorf("build cleare Request error: %s", err.Error())
 	}
 
-	res, err := (&http.Client{}).Do(req)
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return fmt.Errorf("clear Request error: %s", err.Error())
 	}
@@ -129,7 +181,17 @@ func (c *Client) ClearForTest(t *testing.T) {
 
 // Reset restores stub mappings to the defaults defined back in the backing store.
 func (c *Client) Reset() error {
-	res, err := http.Post(fmt.Sprintf("%s/%s/reset", c.url, wiremockAdminMappingsURN), "application/json", nil)
+	return c.ResetCtx(context.Background())
+}
+
+// ResetCtx restores stub mappings to the defaults, honoring ctx's deadline.
+func (c *Client) ResetCtx(ctx context.Context) error {
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminMappingsURN, "reset"), nil)
+	if err != nil {
+		return fmt.Errorf("reset Request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return fmt.Errorf("reset Request error: %s", err.Error())
 	}
@@ -149,7 +211,17 @@ func (c *Client) Reset() error {
 
 // ResetAllScenarios resets back to start of the state of all configured scenarios.
 func (c *Client) ResetAllScenarios() error {
-	res, err := http.Post(fmt.Sprintf("%s/%s/scenarios/reset", c.url, wiremockAdminURN), "application/json", nil)
+	return c.ResetAllScenariosCtx(context.Background())
+}
+
+// ResetAllScenariosCtx resets all configured scenarios, honoring ctx's deadline.
+func (c *Client) ResetAllScenariosCtx(ctx context.Context) error {
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminURN, "scenarios", "reset"), nil)
+	if err != nil {
+		return fmt.Errorf("reset all scenarios Request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return fmt.Errorf("reset all scenarios Request error: %s", err.Error())
 	}
@@ -169,12 +241,22 @@ func (c *Client) ResetAllScenarios() error {
 
 // GetCountRequests gives count requests by criteria.
 func (c *Client) GetCountRequests(r *Request) (int64, error) {
+	return c.GetCountRequestsCtx(context.Background(), r)
+}
+
+// GetCountRequestsCtx gives count requests by criteria, honoring ctx's deadline.
+func (c *Client) GetCountRequestsCtx(ctx context.Context, r *Request) (int64, error) {
 	requestBody, err := r.MarshalJSON()
 	if err != nil {
 		return 0, fmt.Errorf("get count requests: build error: %s", err.Error())
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s/requests/count", c.url, wiremockAdminURN), "application/json", bytes.NewBuffer(requestBody))
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminURN, "requests", "count"), requestBody)
+	if err != nil {
+		return 0, fmt.Errorf("get count requests: build error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return 0, fmt.Errorf("get count requests: %s", err.Error())
 	}
@@ -227,16 +309,20 @@ func (c *Client) VerifyForTest(t *testing.T, r *Request, expectedCount int64) bo
 
 // DeleteStubByID deletes stub by id.
 func (c *Client) DeleteStubByID(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", c.url, wiremockAdminMappingsURN, id), nil)
+	return c.DeleteStubByIDCtx(context.Background(), id)
+}
+
+// DeleteStubByIDCtx deletes stub by id, honoring ctx's deadline.
+func (c *Client) DeleteStubByIDCtx(ctx context.Context, id string) error {
+	req, err := newJSONRequest(ctx, http.MethodDelete, c.adminURL(wiremockAdminMappingsURN, id), nil)
 	if err != nil {
 		return fmt.Errorf("delete stub by id: build request error: %s", err.Error())
 	}
 
-	res, err := (&http.Client{}).Do(req)
+	res, err := c.doRequest(ctx, req)
 	if err != nil {
 		return fmt.Errorf("delete stub by id: request error: %s", err.Error())
 	}
-
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
@@ -265,8 +351,3 @@ func (c Client) BuildTestEndpoint(t *testing.T, apiPath string) (endpoint, expec
 	endpoint = fmt.Sprintf("%s%s", c.url, expectAPIPath)
 	return
 }
-
-// URL ...
-func (c *Client) URL() string {
-	return c.url
-}
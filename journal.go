@@ -0,0 +1,276 @@
+package wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// LoggedRequest is a single request recorded in WireMock's request
+// journal.
+type LoggedRequest struct {
+	Method     string            `json:"method"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+	LoggedDate int64             `json:"loggedDate"`
+}
+
+// MismatchField identifies which part of a request caused a near miss.
+type MismatchField string
+
+const (
+	// MismatchURL means the request URL didn't match.
+	MismatchURL MismatchField = "url"
+	// MismatchMethod means the request method didn't match.
+	MismatchMethod MismatchField = "method"
+	// MismatchHeaders means one or more request headers didn't match.
+	MismatchHeaders MismatchField = "headers"
+	// MismatchBody means the request body didn't match.
+	MismatchBody MismatchField = "body"
+)
+
+// NearMiss describes a logged request that almost, but didn't quite, match
+// a request pattern, along with how close it came.
+type NearMiss struct {
+	Request  LoggedRequest `json:"request"`
+	Distance float64       `json:"distance"`
+	Mismatch MismatchField `json:"-"`
+}
+
+// FindRequests returns every request in the journal matching r, via
+// __admin/requests/find.
+func (c *Client) FindRequests(r *Request) ([]LoggedRequest, error) {
+	return c.FindRequestsCtx(context.Background(), r)
+}
+
+// FindRequestsCtx returns every request in the journal matching r, via
+// __admin/requests/find, honoring ctx's deadline.
+func (c *Client) FindRequestsCtx(ctx context.Context, r *Request) ([]LoggedRequest, error) {
+	requestBody, err := r.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("find requests: build request error: %s", err.Error())
+	}
+
+	bodyBytes, err := c.postJournalRequest(ctx, wiremockAdminURN+"/requests/find", requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("find requests: %s", err.Error())
+	}
+
+	var response struct {
+		Requests []LoggedRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, fmt.Errorf("find requests: unmarshal response error: %s", err.Error())
+	}
+
+	return response.Requests, nil
+}
+
+// GetUnmatchedRequests returns every request that was received but matched
+// no stub mapping, via __admin/requests/unmatched.
+func (c *Client) GetUnmatchedRequests() ([]LoggedRequest, error) {
+	return c.GetUnmatchedRequestsCtx(context.Background())
+}
+
+// GetUnmatchedRequestsCtx returns every request that was received but
+// matched no stub mapping, via __admin/requests/unmatched, honoring ctx's
+// deadline.
+func (c *Client) GetUnmatchedRequestsCtx(ctx context.Context) ([]LoggedRequest, error) {
+	req, err := newJSONRequest(ctx, http.MethodGet, c.adminURL(wiremockAdminURN, "requests", "unmatched"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get unmatched requests: build request error: %s", err.Error())
+	}
+
+	bodyBytes, err := c.doJournalRequest(ctx, req, "get unmatched requests")
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Requests []LoggedRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, fmt.Errorf("get unmatched requests: unmarshal response error: %s", err.Error())
+	}
+
+	return response.Requests, nil
+}
+
+// FindNearMisses returns the logged requests that came closest to matching
+// r without actually matching, ordered from nearest to furthest, via
+// __admin/near-misses/request-pattern. Logging the closest few near misses
+// alongside a failed Verify dramatically shortens the "why didn't my stub
+// match" cycle.
+func (c *Client) FindNearMisses(r *Request) ([]NearMiss, error) {
+	return c.FindNearMissesCtx(context.Background(), r)
+}
+
+// FindNearMissesCtx returns the logged requests that came closest to
+// matching r without actually matching, via
+// __admin/near-misses/request-pattern, honoring ctx's deadline.
+func (c *Client) FindNearMissesCtx(ctx context.Context, r *Request) ([]NearMiss, error) {
+	requestBody, err := r.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("find near misses: build request error: %s", err.Error())
+	}
+
+	bodyBytes, err := c.postJournalRequest(ctx, wiremockAdminURN+"/near-misses/request-pattern", requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses: %s", err.Error())
+	}
+
+	var response struct {
+		NearMisses []struct {
+			Request     LoggedRequest `json:"request"`
+			MatchResult struct {
+				Distance float64 `json:"distance"`
+			} `json:"matchResult"`
+		} `json:"nearMisses"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, fmt.Errorf("find near misses: unmarshal response error: %s", err.Error())
+	}
+
+	nearMisses := make([]NearMiss, 0, len(response.NearMisses))
+	for _, nm := range response.NearMisses {
+		nearMisses = append(nearMisses, NearMiss{
+			Request:  nm.Request,
+			Distance: nm.MatchResult.Distance,
+			Mismatch: classifyMismatch(r, nm.Request),
+		})
+	}
+
+	return nearMisses, nil
+}
+
+// classifyMismatch inspects which part of r's matcher logged didn't
+// satisfy, so a NearMiss can report a single, actionable mismatch
+// category. WireMock's near-miss payload doesn't hand this back, so it's
+// derived client-side by re-evaluating r's matchers against logged.
+func classifyMismatch(r *Request, logged LoggedRequest) MismatchField {
+	if r.method != "" && r.method != logged.Method {
+		return MismatchMethod
+	}
+
+	if !matchesURL(r, logged.URL) {
+		return MismatchURL
+	}
+
+	for header, matcher := range r.headers {
+		value, present := headerValue(logged.Headers, header)
+		if !matchesParam(matcher, value, present) {
+			return MismatchHeaders
+		}
+	}
+
+	for _, matcher := range r.bodyPatterns {
+		if !matchesParam(matcher, logged.Body, true) {
+			return MismatchBody
+		}
+	}
+
+	return ""
+}
+
+func matchesURL(r *Request, url string) bool {
+	if r.urlMatchingStrategy == URLMatchingStrategyPath || r.urlMatchingStrategy == URLMatchingStrategyPathPattern {
+		if i := strings.IndexByte(url, '?'); i >= 0 {
+			url = url[:i]
+		}
+	}
+
+	switch r.urlMatchingStrategy {
+	case URLMatchingStrategyMatching, URLMatchingStrategyPathPattern:
+		re, err := regexp.Compile(r.url)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(url)
+	default:
+		return url == r.url
+	}
+}
+
+func matchesParam(m ParamMatcher, value string, present bool) bool {
+	switch m.strategy {
+	case MatchingStrategyAbsent:
+		return !present
+	case MatchingStrategyMatches:
+		if !present {
+			return false
+		}
+		re, err := regexp.Compile(fmt.Sprintf("%v", m.value))
+		if err != nil {
+			return false
+		}
+		return re.MatchString(value)
+	case MatchingStrategyContains:
+		return present && strings.Contains(value, fmt.Sprintf("%v", m.value))
+	default: // MatchingStrategyEqualTo
+		return present && value == fmt.Sprintf("%v", m.value)
+	}
+}
+
+func headerValue(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// ResetRequestJournal clears the request journal via __admin/requests.
+func (c *Client) ResetRequestJournal() error {
+	return c.ResetRequestJournalCtx(context.Background())
+}
+
+// ResetRequestJournalCtx clears the request journal via __admin/requests,
+// honoring ctx's deadline.
+func (c *Client) ResetRequestJournalCtx(ctx context.Context) error {
+	req, err := newJSONRequest(ctx, http.MethodDelete, c.adminURL(wiremockAdminURN, "requests"), nil)
+	if err != nil {
+		return fmt.Errorf("reset request journal: build request error: %s", err.Error())
+	}
+
+	_, err = c.doJournalRequest(ctx, req, "reset request journal")
+	return err
+}
+
+func (c *Client) postJournalRequest(ctx context.Context, urn string, requestBody []byte) ([]byte, error) {
+	req, err := newJSONRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s", c.url, urn), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("build request error: %s", err.Error())
+	}
+
+	return c.doJournalRequest(ctx, req, "")
+}
+
+func (c *Client) doJournalRequest(ctx context.Context, req *http.Request, op string) ([]byte, error) {
+	prefix := ""
+	if op != "" {
+		prefix = op + ": "
+	}
+
+	res, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%srequest error: %s", prefix, err.Error())
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%sread response error: %s", prefix, err.Error())
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%sbad response status: %d, response: %s", prefix, res.StatusCode, string(bodyBytes))
+	}
+
+	return bodyBytes, nil
+}
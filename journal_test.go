@@ -0,0 +1,55 @@
+package wiremock
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClassifyMismatch(t *testing.T) {
+	logged := LoggedRequest{
+		Method:  http.MethodGet,
+		URL:     "/ping",
+		Headers: map[string]string{"X-Token": "abc"},
+		Body:    `{"ok":true}`,
+	}
+
+	tests := []struct {
+		name string
+		req  *Request
+		want MismatchField
+	}{
+		{
+			name: "full match",
+			req:  NewRequest(http.MethodGet, URLMatchingStrategyEqualTo, "/ping"),
+			want: "",
+		},
+		{
+			name: "method mismatch",
+			req:  NewRequest(http.MethodPost, URLMatchingStrategyEqualTo, "/ping"),
+			want: MismatchMethod,
+		},
+		{
+			name: "url mismatch",
+			req:  NewRequest(http.MethodGet, URLMatchingStrategyEqualTo, "/pong"),
+			want: MismatchURL,
+		},
+		{
+			name: "header mismatch",
+			req:  NewRequest(http.MethodGet, URLMatchingStrategyEqualTo, "/ping").WithHeader("X-Token", EqualTo("xyz")),
+			want: MismatchHeaders,
+		},
+		{
+			name: "body mismatch",
+			req:  NewRequest(http.MethodGet, URLMatchingStrategyEqualTo, "/ping").WithBodyPattern(EqualTo(`{"ok":false}`)),
+			want: MismatchBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyMismatch(tt.req, logged); got != tt.want {
+				t.Fatalf("classifyMismatch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
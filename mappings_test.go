@@ -0,0 +1,63 @@
+package wiremock
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestImportMappingsDefaultsDuplicatePolicyToOverwrite(t *testing.T) {
+	var captured struct {
+		ImportOptions struct {
+			DuplicatePolicy string `json:"duplicatePolicy"`
+		} `json:"importOptions"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read request body error: %s", err)
+		}
+		if err := json.Unmarshal(body, &captured); err != nil {
+			t.Fatalf("unmarshal request body error: %s", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	bundle := strings.NewReader(`{"mappings":[],"meta":{"total":0}}`)
+
+	if err := client.ImportMappings(bundle, ImportOptions{}); err != nil {
+		t.Fatalf("import mappings error: %s", err)
+	}
+
+	if captured.ImportOptions.DuplicatePolicy != string(DuplicatePolicyOverwrite) {
+		t.Fatalf("expected an empty DuplicatePolicy to default to %q, got %q", DuplicatePolicyOverwrite, captured.ImportOptions.DuplicatePolicy)
+	}
+}
+
+func TestExportMappingsWritesEnvelopeVerbatim(t *testing.T) {
+	envelope := `{"mappings":[{"id":"a"}],"meta":{"total":1}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(envelope))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	var buf bytes.Buffer
+
+	if err := client.ExportMappings(&buf); err != nil {
+		t.Fatalf("export mappings error: %s", err)
+	}
+
+	if buf.String() != envelope {
+		t.Fatalf("expected envelope %q to round-trip verbatim, got %q", envelope, buf.String())
+	}
+}
@@ -0,0 +1,50 @@
+package wiremock
+
+import "encoding/json"
+
+// MatchingStrategy is the WireMock matcher keyword (e.g. "equalTo",
+// "matches") used as the JSON key for a ParamMatcher.
+type MatchingStrategy string
+
+const (
+	// MatchingStrategyEqualTo matches on exact equality.
+	MatchingStrategyEqualTo MatchingStrategy = "equalTo"
+	// MatchingStrategyMatches matches on a regular expression.
+	MatchingStrategyMatches MatchingStrategy = "matches"
+	// MatchingStrategyContains matches when the value contains a substring.
+	MatchingStrategyContains MatchingStrategy = "contains"
+	// MatchingStrategyAbsent matches when the field is absent.
+	MatchingStrategyAbsent MatchingStrategy = "absent"
+)
+
+// ParamMatcher describes how a single header, query parameter, cookie or
+// request body should be matched.
+type ParamMatcher struct {
+	strategy MatchingStrategy
+	value    interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ParamMatcher) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{string(p.strategy): p.value})
+}
+
+// EqualTo matches a value exactly.
+func EqualTo(value string) ParamMatcher {
+	return ParamMatcher{strategy: MatchingStrategyEqualTo, value: value}
+}
+
+// Matching matches a value against a regular expression.
+func Matching(regexp string) ParamMatcher {
+	return ParamMatcher{strategy: MatchingStrategyMatches, value: regexp}
+}
+
+// Contains matches a value containing the given substring.
+func Contains(substr string) ParamMatcher {
+	return ParamMatcher{strategy: MatchingStrategyContains, value: substr}
+}
+
+// Absent matches when the field is not present on the request.
+func Absent() ParamMatcher {
+	return ParamMatcher{strategy: MatchingStrategyAbsent, value: true}
+}
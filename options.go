@@ -0,0 +1,144 @@
+package wiremock
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client constructed with NewClientWithOptions.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for all admin requests.
+// Any timeout or transport configured on it is used as-is. Options applied
+// after it (WithTimeout, WithRetry, WithBasicAuth, WithBearerToken) modify
+// this client; applying it after them replaces the client they configured.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout applied to every admin request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetry retries admin requests that fail with a connection error or a
+// 5xx response, up to maxAttempts times, with exponential backoff and
+// jitter starting at backoff. This matters because CI pipelines routinely
+// race against a WireMock container that's still warming up; without it,
+// any transient failure aborts the test.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = &retryTransport{
+			next:        transportOrDefault(c.httpClient.Transport),
+			maxAttempts: maxAttempts,
+			backoff:     backoff,
+		}
+	}
+}
+
+// WithBasicAuth sends the given basic auth credentials with every admin
+// request.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = &authTransport{
+			next: transportOrDefault(c.httpClient.Transport),
+			setAuth: func(req *http.Request) {
+				req.SetBasicAuth(username, password)
+			},
+		}
+	}
+}
+
+// WithBearerToken sends the given bearer token with every admin request.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = &authTransport{
+			next: transportOrDefault(c.httpClient.Transport),
+			setAuth: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer "+token)
+			},
+		}
+	}
+}
+
+func transportOrDefault(t http.RoundTripper) http.RoundTripper {
+	if t != nil {
+		return t
+	}
+	return http.DefaultTransport
+}
+
+type authTransport struct {
+	next    http.RoundTripper
+	setAuth func(*http.Request)
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.setAuth(req)
+	return t.next.RoundTrip(req)
+}
+
+type retryTransport struct {
+	next        http.RoundTripper
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.maxAttempts < 1 {
+		return nil, fmt.Errorf("wiremock: WithRetry: maxAttempts must be >= 1, got %d", t.maxAttempts)
+	}
+
+	var (
+		res *http.Response
+		err error
+	)
+
+	ctx := req.Context()
+
+	for attempt := 0; attempt < t.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(t.retryDelay(attempt)):
+			}
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		reqCopy := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			reqCopy.Body = body
+		}
+
+		res, err = t.next.RoundTrip(reqCopy)
+		if err == nil && res.StatusCode < http.StatusInternalServerError {
+			return res, nil
+		}
+
+		if err == nil && attempt < t.maxAttempts-1 {
+			res.Body.Close()
+		}
+	}
+
+	return res, err
+}
+
+func (t *retryTransport) retryDelay(attempt int) time.Duration {
+	delay := t.backoff << uint(attempt-1)
+	jitter := time.Duration(rand.Int63n(int64(delay) + 1))
+	return delay/2 + jitter/2
+}
@@ -0,0 +1,55 @@
+package wiremock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportAbortsOnContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	transport := &retryTransport{next: http.DefaultTransport, maxAttempts: 5, backoff: 200 * time.Millisecond}
+	client := &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("build request error: %s", err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(req)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error once the context deadline passed, got nil")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("retry kept sleeping past the context deadline: took %s", elapsed)
+	}
+}
+
+func TestRetryTransportRejectsNonPositiveMaxAttempts(t *testing.T) {
+	transport := &retryTransport{next: http.DefaultTransport, maxAttempts: 0, backoff: time.Millisecond}
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.invalid", nil)
+	if err != nil {
+		t.Fatalf("build request error: %s", err)
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err == nil {
+		t.Fatal("expected an error for maxAttempts < 1, got nil")
+	}
+	if res != nil {
+		t.Fatalf("expected a nil response alongside the error, got %v", res)
+	}
+}
@@ -0,0 +1,211 @@
+package wiremock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const wiremockAdminRecordingsURN = "__admin/recordings"
+
+// RequestBodyPattern selects how recorded request bodies are matched when
+// WireMock generates stub mappings for them.
+type RequestBodyPattern string
+
+const (
+	// RequestBodyPatternAuto lets WireMock pick the matcher based on the
+	// request's content type.
+	RequestBodyPatternAuto RequestBodyPattern = "auto"
+	// RequestBodyPatternEqualToJSON matches recorded JSON bodies with
+	// equalToJson.
+	RequestBodyPatternEqualToJSON RequestBodyPattern = "equalToJson"
+	// RequestBodyPatternEqualToXML matches recorded XML bodies with
+	// equalToXml.
+	RequestBodyPatternEqualToXML RequestBodyPattern = "equalToXml"
+)
+
+// CaptureHeaderConfig controls how a single header is captured into
+// generated stub mappings while recording.
+type CaptureHeaderConfig struct {
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+}
+
+// RecordingOptions controls the behaviour of Client.StartRecording and
+// Client.TakeSnapshot.
+type RecordingOptions struct {
+	// Filters restricts which proxied requests are turned into stub
+	// mappings.
+	Filters *Request
+	// CaptureHeaders lists request headers to capture on generated stub
+	// mappings, keyed by header name.
+	CaptureHeaders map[string]CaptureHeaderConfig
+	// RequestBodyPattern selects how recorded request bodies are matched.
+	RequestBodyPattern RequestBodyPattern
+	// PersistStubs controls whether generated stub mappings are persisted
+	// to disk as well as held in memory.
+	PersistStubs bool
+	// RepeatsAsScenarios groups repeated requests to the same endpoint into
+	// a WireMock scenario instead of generating duplicate stub mappings.
+	RepeatsAsScenarios bool
+}
+
+// SnapshotOptions controls the behaviour of Client.TakeSnapshot. It shares
+// the same shape as RecordingOptions because WireMock's snapshot endpoint
+// accepts the same recording spec.
+type SnapshotOptions = RecordingOptions
+
+func (o RecordingOptions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.specMap())
+}
+
+func (o RecordingOptions) specMap() map[string]interface{} {
+	spec := map[string]interface{}{}
+
+	if o.Filters != nil {
+		spec["filters"] = o.Filters
+	}
+	if len(o.CaptureHeaders) > 0 {
+		spec["captureHeaders"] = o.CaptureHeaders
+	}
+	if o.RequestBodyPattern != "" {
+		spec["requestBodyPattern"] = map[string]string{"matcher": string(o.RequestBodyPattern)}
+	}
+	spec["persist"] = o.PersistStubs
+	spec["repeatsAsScenarios"] = o.RepeatsAsScenarios
+
+	return spec
+}
+
+// StartRecording begins proxying and recording requests against
+// targetBaseURL via __admin/recordings/start.
+func (c *Client) StartRecording(targetBaseURL string, opts RecordingOptions) error {
+	return c.StartRecordingCtx(context.Background(), targetBaseURL, opts)
+}
+
+// StartRecordingCtx begins proxying and recording requests against
+// targetBaseURL via __admin/recordings/start, honoring ctx's deadline.
+func (c *Client) StartRecordingCtx(ctx context.Context, targetBaseURL string, opts RecordingOptions) error {
+	spec := opts.specMap()
+	spec["targetBaseUrl"] = targetBaseURL
+
+	requestBody, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("start recording: build request error: %s", err.Error())
+	}
+
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminRecordingsURN, "start"), requestBody)
+	if err != nil {
+		return fmt.Errorf("start recording: build request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("start recording: request error: %s", err.Error())
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("start recording: read response error: %s", err.Error())
+		}
+
+		return fmt.Errorf("start recording: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// StopRecording stops the current recording session via
+// __admin/recordings/stop and returns the stub mappings WireMock generated
+// for every proxied request that matched the recording filters.
+func (c *Client) StopRecording() ([]*StubRule, error) {
+	return c.StopRecordingCtx(context.Background())
+}
+
+// StopRecordingCtx stops the current recording session via
+// __admin/recordings/stop, honoring ctx's deadline, and returns the stub
+// mappings WireMock generated for every proxied request that matched the
+// recording filters.
+func (c *Client) StopRecordingCtx(ctx context.Context) ([]*StubRule, error) {
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminRecordingsURN, "stop"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("stop recording: build request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("stop recording: request error: %s", err.Error())
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("stop recording: read response error: %s", err.Error())
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("stop recording: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	}
+
+	return unmarshalMappingsEnvelope(bodyBytes, "stop recording")
+}
+
+// TakeSnapshot generates stub mappings from the requests already recorded
+// in the journal, via __admin/recordings/snapshot, without starting or
+// stopping a recording session.
+func (c *Client) TakeSnapshot(opts SnapshotOptions) ([]*StubRule, error) {
+	return c.TakeSnapshotCtx(context.Background(), opts)
+}
+
+// TakeSnapshotCtx generates stub mappings from the requests already
+// recorded in the journal, via __admin/recordings/snapshot, honoring ctx's
+// deadline, without starting or stopping a recording session.
+func (c *Client) TakeSnapshotCtx(ctx context.Context, opts SnapshotOptions) ([]*StubRule, error) {
+	requestBody, err := opts.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("take snapshot: build request error: %s", err.Error())
+	}
+
+	req, err := newJSONRequest(ctx, http.MethodPost, c.adminURL(wiremockAdminRecordingsURN, "snapshot"), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("take snapshot: build request error: %s", err.Error())
+	}
+
+	res, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("take snapshot: request error: %s", err.Error())
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("take snapshot: read response error: %s", err.Error())
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("take snapshot: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	}
+
+	return unmarshalMappingsEnvelope(bodyBytes, "take snapshot")
+}
+
+func unmarshalMappingsEnvelope(bodyBytes []byte, op string) ([]*StubRule, error) {
+	var envelope mappingsEnvelope
+	if err := json.Unmarshal(bodyBytes, &envelope); err != nil {
+		return nil, fmt.Errorf("%s: unmarshal response error: %s", op, err.Error())
+	}
+
+	stubRules := make([]*StubRule, 0, len(envelope.Mappings))
+	for _, rawMapping := range envelope.Mappings {
+		stubRule, err := NewStubRuleFromJSON(rawMapping)
+		if err != nil {
+			return nil, fmt.Errorf("%s: parse mapping error: %s", op, err.Error())
+		}
+		stubRules = append(stubRules, stubRule)
+	}
+
+	return stubRules, nil
+}
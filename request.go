@@ -0,0 +1,92 @@
+package wiremock
+
+import "encoding/json"
+
+// URLMatchingStrategy is the WireMock JSON field used to match the request
+// URL (e.g. "url", "urlPattern", "urlPath", "urlPathPattern").
+type URLMatchingStrategy string
+
+const (
+	// URLMatchingStrategyEqualTo matches the URL exactly.
+	URLMatchingStrategyEqualTo URLMatchingStrategy = "url"
+	// URLMatchingStrategyMatching matches the URL against a regular
+	// expression.
+	URLMatchingStrategyMatching URLMatchingStrategy = "urlPattern"
+	// URLMatchingStrategyPath matches the URL path exactly, ignoring the
+	// query string.
+	URLMatchingStrategyPath URLMatchingStrategy = "urlPath"
+	// URLMatchingStrategyPathPattern matches the URL path against a regular
+	// expression, ignoring the query string.
+	URLMatchingStrategyPathPattern URLMatchingStrategy = "urlPathPattern"
+)
+
+// Request describes a request matcher, used both to stub requests and to
+// query the request journal.
+type Request struct {
+	method              string
+	urlMatchingStrategy URLMatchingStrategy
+	url                 string
+	headers             map[string]ParamMatcher
+	queryParams         map[string]ParamMatcher
+	cookies             map[string]ParamMatcher
+	bodyPatterns        []ParamMatcher
+}
+
+// NewRequest returns a *Request matching the given method and URL.
+func NewRequest(method string, urlMatchingStrategy URLMatchingStrategy, url string) *Request {
+	return &Request{
+		method:              method,
+		urlMatchingStrategy: urlMatchingStrategy,
+		url:                 url,
+		headers:             map[string]ParamMatcher{},
+		queryParams:         map[string]ParamMatcher{},
+		cookies:             map[string]ParamMatcher{},
+	}
+}
+
+// WithHeader adds a header matcher to the request.
+func (r *Request) WithHeader(header string, matcher ParamMatcher) *Request {
+	r.headers[header] = matcher
+	return r
+}
+
+// WithQueryParam adds a query parameter matcher to the request.
+func (r *Request) WithQueryParam(param string, matcher ParamMatcher) *Request {
+	r.queryParams[param] = matcher
+	return r
+}
+
+// WithCookie adds a cookie matcher to the request.
+func (r *Request) WithCookie(cookie string, matcher ParamMatcher) *Request {
+	r.cookies[cookie] = matcher
+	return r
+}
+
+// WithBodyPattern adds a body matcher to the request.
+func (r *Request) WithBodyPattern(matcher ParamMatcher) *Request {
+	r.bodyPatterns = append(r.bodyPatterns, matcher)
+	return r
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Request) MarshalJSON() ([]byte, error) {
+	requestMap := map[string]interface{}{
+		"method":                      r.method,
+		string(r.urlMatchingStrategy): r.url,
+	}
+
+	if len(r.headers) > 0 {
+		requestMap["headers"] = r.headers
+	}
+	if len(r.queryParams) > 0 {
+		requestMap["queryParameters"] = r.queryParams
+	}
+	if len(r.cookies) > 0 {
+		requestMap["cookies"] = r.cookies
+	}
+	if len(r.bodyPatterns) > 0 {
+		requestMap["bodyPatterns"] = r.bodyPatterns
+	}
+
+	return json.Marshal(requestMap)
+}
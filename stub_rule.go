@@ -0,0 +1,169 @@
+package wiremock
+
+import (
+	"encoding/json"
+)
+
+// Response describes the response a StubRule returns when its request
+// matcher hits.
+type Response struct {
+	body                  string
+	jsonBody              interface{}
+	status                int64
+	headers               map[string]string
+	transformers          []string
+	transformerParameters map[string]interface{}
+	fault                 string
+}
+
+// StubRule is a single stub mapping: a request matcher paired with the
+// response to return when it matches.
+type StubRule struct {
+	uuid                  string
+	request               *Request
+	response              *Response
+	priority              int64
+	scenarioName          string
+	requiredScenarioState string
+	newScenarioState      string
+
+	// raw holds the verbatim stub mapping JSON when this StubRule was built
+	// from an external source (the mappings API, a recording, or a
+	// snapshot) rather than through the fluent builders below. When set,
+	// MarshalJSON returns it unmodified so the mapping round-trips exactly.
+	raw json.RawMessage
+}
+
+// NewStubRule returns a *StubRule matching the given method and URL.
+func NewStubRule(method string, urlMatchingStrategy URLMatchingStrategy, url string) *StubRule {
+	return &StubRule{
+		uuid:    newUUID(),
+		request: NewRequest(method, urlMatchingStrategy, url),
+		response: &Response{
+			status: 200,
+		},
+	}
+}
+
+// UUID returns the stub mapping's id.
+func (s *StubRule) UUID() string {
+	return s.uuid
+}
+
+// WithHeader adds a header matcher to the stub's request.
+func (s *StubRule) WithHeader(header string, matcher ParamMatcher) *StubRule {
+	s.request.WithHeader(header, matcher)
+	return s
+}
+
+// WithQueryParam adds a query parameter matcher to the stub's request.
+func (s *StubRule) WithQueryParam(param string, matcher ParamMatcher) *StubRule {
+	s.request.WithQueryParam(param, matcher)
+	return s
+}
+
+// WithBodyPattern adds a body matcher to the stub's request.
+func (s *StubRule) WithBodyPattern(matcher ParamMatcher) *StubRule {
+	s.request.WithBodyPattern(matcher)
+	return s
+}
+
+// AtPriority sets the stub's match priority; lower values are matched
+// first.
+func (s *StubRule) AtPriority(priority int64) *StubRule {
+	s.priority = priority
+	return s
+}
+
+// InScenario assigns the stub to a named scenario.
+func (s *StubRule) InScenario(scenarioName string) *StubRule {
+	s.scenarioName = scenarioName
+	return s
+}
+
+// WhenScenarioStateIs restricts the stub to matching only when the
+// scenario is in the given state.
+func (s *StubRule) WhenScenarioStateIs(state string) *StubRule {
+	s.requiredScenarioState = state
+	return s
+}
+
+// WillSetStateTo transitions the scenario to the given state once the stub
+// matches.
+func (s *StubRule) WillSetStateTo(state string) *StubRule {
+	s.newScenarioState = state
+	return s
+}
+
+// WillReturn sets the stub's response body, headers and status code. Any
+// transformers, transformer parameters or fault configured via
+// WithResponseTemplating, WithTransformer or WithFault are preserved
+// regardless of call order.
+func (s *StubRule) WillReturn(body string, headers map[string]string, status int64) *StubRule {
+	s.response.body = body
+	s.response.jsonBody = nil
+	s.response.headers = headers
+	s.response.status = status
+	return s
+}
+
+// WillReturnJSON sets the stub's response body to the JSON encoding of
+// body, alongside headers and a status code. Any transformers, transformer
+// parameters or fault configured via WithResponseTemplating, WithTransformer
+// or WithFault are preserved regardless of call order.
+func (s *StubRule) WillReturnJSON(body interface{}, headers map[string]string, status int64) *StubRule {
+	s.response.jsonBody = body
+	s.response.body = ""
+	s.response.headers = headers
+	s.response.status = status
+	return s
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *StubRule) MarshalJSON() ([]byte, error) {
+	if s.raw != nil {
+		return s.raw, nil
+	}
+
+	responseMap := map[string]interface{}{
+		"status": s.response.status,
+	}
+	if s.response.jsonBody != nil {
+		responseMap["jsonBody"] = s.response.jsonBody
+	} else {
+		responseMap["body"] = s.response.body
+	}
+	if len(s.response.headers) > 0 {
+		responseMap["headers"] = s.response.headers
+	}
+	if len(s.response.transformers) > 0 {
+		responseMap["transformers"] = s.response.transformers
+	}
+	if len(s.response.transformerParameters) > 0 {
+		responseMap["transformerParameters"] = s.response.transformerParameters
+	}
+	if s.response.fault != "" {
+		responseMap["fault"] = s.response.fault
+	}
+
+	stubRuleMap := map[string]interface{}{
+		"uuid":     s.uuid,
+		"request":  s.request,
+		"response": responseMap,
+	}
+
+	if s.priority != 0 {
+		stubRuleMap["priority"] = s.priority
+	}
+	if s.scenarioName != "" {
+		stubRuleMap["scenarioName"] = s.scenarioName
+	}
+	if s.requiredScenarioState != "" {
+		stubRuleMap["requiredScenarioState"] = s.requiredScenarioState
+	}
+	if s.newScenarioState != "" {
+		stubRuleMap["newScenarioState"] = s.newScenarioState
+	}
+
+	return json.Marshal(stubRuleMap)
+}
@@ -0,0 +1,25 @@
+package wiremock
+
+import "encoding/json"
+
+// NewStubRuleFromJSON wraps a raw stub mapping, such as one read back from
+// __admin/mappings or a recording/snapshot response, in a *StubRule. The
+// result round-trips through MarshalJSON unmodified, so it can be fed
+// straight into StubFor without having to rebuild it through the fluent
+// builders.
+func NewStubRuleFromJSON(raw json.RawMessage) (*StubRule, error) {
+	var id struct {
+		UUID string `json:"uuid,omitempty"`
+		ID   string `json:"id,omitempty"`
+	}
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return nil, err
+	}
+
+	uuid := id.UUID
+	if uuid == "" {
+		uuid = id.ID
+	}
+
+	return &StubRule{uuid: uuid, raw: raw}, nil
+}
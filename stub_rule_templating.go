@@ -0,0 +1,71 @@
+package wiremock
+
+// FaultType is one of WireMock's fault modes, used to stub a broken or
+// faulty response for exercising client-side error handling.
+type FaultType string
+
+const (
+	// FaultEmptyResponse closes the connection without writing anything.
+	FaultEmptyResponse FaultType = "EMPTY_RESPONSE"
+	// FaultMalformedResponseChunk sends a malformed chunk of response data
+	// then closes the connection.
+	FaultMalformedResponseChunk FaultType = "MALFORMED_RESPONSE_CHUNK"
+	// FaultRandomDataThenClose sends random garbage then closes the
+	// connection.
+	FaultRandomDataThenClose FaultType = "RANDOM_DATA_THEN_CLOSE"
+	// FaultConnectionResetByPeer resets the connection immediately.
+	FaultConnectionResetByPeer FaultType = "CONNECTION_RESET_BY_PEER"
+)
+
+const responseTemplateTransformer = "response-template"
+
+// WithResponseTemplating enables WireMock's response-template transformer
+// on the stub's response, so its body, headers and status can reference
+// the request via Handlebars-style templates.
+func (s *StubRule) WithResponseTemplating() *StubRule {
+	s.addTransformer(responseTemplateTransformer)
+	return s
+}
+
+// WithTransformer enables a named response transformer extension on the
+// stub's response.
+func (s *StubRule) WithTransformer(name string) *StubRule {
+	s.addTransformer(name)
+	return s
+}
+
+// WithTransformerParameter sets a parameter passed to the stub's response
+// transformers.
+func (s *StubRule) WithTransformerParameter(k string, v interface{}) *StubRule {
+	if s.response.transformerParameters == nil {
+		s.response.transformerParameters = map[string]interface{}{}
+	}
+	s.response.transformerParameters[k] = v
+	return s
+}
+
+// TemplatedBody sets the stub's response body to tmpl, a Handlebars-style
+// WireMock response template, and enables the response-template
+// transformer that renders it.
+func (s *StubRule) TemplatedBody(tmpl string) *StubRule {
+	s.response.body = tmpl
+	s.response.jsonBody = nil
+	s.addTransformer(responseTemplateTransformer)
+	return s
+}
+
+// WithFault makes the stub's response fail in the given way, for exercising
+// client-side error handling.
+func (s *StubRule) WithFault(fault FaultType) *StubRule {
+	s.response.fault = string(fault)
+	return s
+}
+
+func (s *StubRule) addTransformer(name string) {
+	for _, t := range s.response.transformers {
+		if t == name {
+			return
+		}
+	}
+	s.response.transformers = append(s.response.transformers, name)
+}
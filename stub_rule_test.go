@@ -0,0 +1,40 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestWillReturnPreservesFaultAndTransformers(t *testing.T) {
+	stub := NewStubRule(http.MethodGet, URLMatchingStrategyEqualTo, "/ping").
+		WithFault(FaultEmptyResponse).
+		WithResponseTemplating().
+		WillReturn("pong", nil, 200)
+
+	bodyBytes, err := stub.MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+
+	var mapping struct {
+		Response struct {
+			Fault        string   `json:"fault"`
+			Transformers []string `json:"transformers"`
+			Body         string   `json:"body"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(bodyBytes, &mapping); err != nil {
+		t.Fatalf("unmarshal error: %s", err)
+	}
+
+	if mapping.Response.Fault != string(FaultEmptyResponse) {
+		t.Fatalf("expected fault %q to survive WillReturn, got %q", FaultEmptyResponse, mapping.Response.Fault)
+	}
+	if len(mapping.Response.Transformers) != 1 || mapping.Response.Transformers[0] != responseTemplateTransformer {
+		t.Fatalf("expected transformers to survive WillReturn, got %v", mapping.Response.Transformers)
+	}
+	if mapping.Response.Body != "pong" {
+		t.Fatalf("expected body %q, got %q", "pong", mapping.Response.Body)
+	}
+}
@@ -0,0 +1,91 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// TestClient is a Client handle scoped to a single test. It is obtained
+// via Client.T and is safe to call repeatedly within the same test; the
+// underlying stub cleanup is registered exactly once.
+type TestClient struct {
+	client *Client
+	t      *testing.T
+	testID string
+}
+
+// T returns a TestClient scoped to t. On first use for a given t, it
+// registers t.Cleanup(func(){ c.ClearForTest(t) }) so stubs are always torn
+// down even if the test never calls ClearForTest itself.
+func (c *Client) T(t *testing.T) *TestClient {
+	c.stubMutex.Lock()
+	defer c.stubMutex.Unlock()
+
+	if c.testClients == nil {
+		c.testClients = make(map[*testing.T]*TestClient)
+	}
+
+	if tc, ok := c.testClients[t]; ok {
+		return tc
+	}
+
+	tc := &TestClient{client: c, t: t, testID: CreateTestID(t)}
+	c.testClients[t] = tc
+
+	t.Cleanup(func() {
+		c.ClearForTest(t)
+
+		c.stubMutex.Lock()
+		delete(c.testClients, t)
+		c.stubMutex.Unlock()
+	})
+
+	return tc
+}
+
+// StubFor registers stubRule for this test. If the rule belongs to a
+// scenario, the scenario name is prefixed with the test's id so that
+// parallel t.Parallel() tests never collide on scenario state.
+func (tc *TestClient) StubFor(stubRule *StubRule) {
+	tc.prefixScenario(stubRule)
+	tc.client.StubForTest(tc.t, stubRule)
+}
+
+func (tc *TestClient) prefixScenario(stubRule *StubRule) {
+	if stubRule.raw != nil {
+		var mapping map[string]json.RawMessage
+		var name string
+		if json.Unmarshal(stubRule.raw, &mapping) != nil || json.Unmarshal(mapping["scenarioName"], &name) != nil || name == "" {
+			return
+		}
+		mapping["scenarioName"], _ = json.Marshal(fmt.Sprintf("%s:%s", tc.testID, name))
+		if raw, err := json.Marshal(mapping); err == nil {
+			stubRule.raw = raw
+		}
+		return
+	}
+
+	if stubRule.scenarioName == "" {
+		return
+	}
+	stubRule.scenarioName = fmt.Sprintf("%s:%s", tc.testID, stubRule.scenarioName)
+}
+
+// VerifyNoUnmatched fails the test if any request tagged with this test's
+// X-Wiremock-Test-Id went unmatched.
+func (tc *TestClient) VerifyNoUnmatched() {
+	t := tc.t
+
+	unmatched, err := tc.client.GetUnmatchedRequests()
+	if err != nil {
+		t.Fatalf("verify no unmatched: %s", err)
+	}
+
+	for _, req := range unmatched {
+		if testID, _ := headerValue(req.Headers, TestIDRequestHeader); testID != tc.testID {
+			continue
+		}
+		t.Errorf("unmatched request for this test: %s %s", req.Method, req.URL)
+	}
+}
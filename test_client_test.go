@@ -0,0 +1,56 @@
+package wiremock
+
+import "testing"
+
+func TestClientTReturnsSameInstanceForSameTest(t *testing.T) {
+	c := NewClient("http://example.invalid")
+
+	first := c.T(t)
+	second := c.T(t)
+
+	if first != second {
+		t.Fatalf("expected repeated Client.T(t) calls to return the same *TestClient, got %p and %p", first, second)
+	}
+}
+
+func TestClientTCleanupRemovesTestClient(t *testing.T) {
+	c := NewClient("http://example.invalid")
+
+	t.Run("scoped", func(t *testing.T) {
+		c.T(t)
+		c.T(t)
+
+		if _, ok := c.testClients[t]; !ok {
+			t.Fatal("expected Client.T(t) to register a TestClient before cleanup runs")
+		}
+	})
+
+	if len(c.testClients) != 0 {
+		t.Fatalf("expected the subtest's TestClient to be removed once its t.Cleanup ran, got %d left", len(c.testClients))
+	}
+}
+
+func TestPrefixScenarioPrependsTestID(t *testing.T) {
+	c := NewClient("http://example.invalid")
+	tc := c.T(t)
+
+	stub := NewStubRule("GET", URLMatchingStrategyEqualTo, "/ping").InScenario("checkout")
+	tc.prefixScenario(stub)
+
+	want := CreateTestID(t) + ":checkout"
+	if stub.scenarioName != want {
+		t.Fatalf("expected scenario name %q, got %q", want, stub.scenarioName)
+	}
+}
+
+func TestPrefixScenarioLeavesUnscoped(t *testing.T) {
+	c := NewClient("http://example.invalid")
+	tc := c.T(t)
+
+	stub := NewStubRule("GET", URLMatchingStrategyEqualTo, "/ping")
+	tc.prefixScenario(stub)
+
+	if stub.scenarioName != "" {
+		t.Fatalf("expected a stub with no scenario to stay unscoped, got %q", stub.scenarioName)
+	}
+}